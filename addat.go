@@ -0,0 +1,35 @@
+package pewma
+
+import (
+	"math"
+	"time"
+)
+
+// AddAt behaves like Add, but scales the effective alpha by the wall-clock
+// time elapsed since the previous sample instead of treating every call as
+// one equally-weighted tick, for streams with irregular sampling intervals
+// (network drops, bursty telemetry, restarts). Like Add, it returns a new
+// PEWMA object and leaves the receiver untouched.
+//
+// The elapsed time dt is measured against config.samplingPeriod, giving
+// alpha_eff = 1 - (1-alpha)^(dt/samplingPeriod), where alpha is the weight
+// factors.New gives the previously fitted s1 and s2. A gap equal to one
+// period reproduces the ordinary per-tick alpha, a longer gap pushes
+// alpha_eff toward 1 (the previous fit dominates and the new sample counts
+// for less), and a shorter gap pushes it toward 0 (the new sample dominates).
+// The first sample has no prior timestamp to compare against, so it falls
+// back to the ordinary per-tick alpha.
+func (p *PEWMA) AddAt(t time.Time, v Value) *PEWMA {
+	alpha := p.alpha(v)
+	if !p.lastTS.IsZero() && p.config.samplingPeriod > 0 {
+		dt := t.Sub(p.lastTS)
+		alpha = Value(1 - math.Pow(1-float64(alpha), dt.Seconds()/p.config.samplingPeriod.Seconds()))
+	}
+
+	return &PEWMA{
+		config:   p.config,
+		captured: p.pushAndPop(v),
+		factors:  p.factors.New(alpha, v),
+		lastTS:   t,
+	}
+}
@@ -0,0 +1,69 @@
+package pewma
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAddAtFirstSampleFallsBackToPerTickAlpha(t *testing.T) {
+	cfg, err := NewConfig(5, 0.5, 0.5, time.Second)
+	if err != nil {
+		t.Fatalf("NewConfig() error = %v", err)
+	}
+	base := time.Unix(0, 0)
+
+	viaAdd := New(cfg).Add(10)
+	viaAddAt := New(cfg).AddAt(base, 10)
+
+	if viaAdd.Value() != viaAddAt.Value() {
+		t.Errorf("AddAt on first sample: Value() = %v, want %v (same as Add)", viaAddAt.Value(), viaAdd.Value())
+	}
+}
+
+func TestAddAtOnePeriodGapMatchesOrdinaryAdd(t *testing.T) {
+	cfg, err := NewConfig(1, 0.9, 0, time.Second)
+	if err != nil {
+		t.Fatalf("NewConfig() error = %v", err)
+	}
+	base := time.Unix(0, 0)
+
+	viaAdd := New(cfg).AddAt(base, 10).Add(20)
+	viaAddAt := New(cfg).AddAt(base, 10).AddAt(base.Add(time.Second), 20)
+
+	if viaAdd.Value() != viaAddAt.Value() {
+		t.Errorf("AddAt with a one-period gap: Value() = %v, want %v (same as an ordinary Add)", viaAddAt.Value(), viaAdd.Value())
+	}
+}
+
+func TestAddAtDifferentGapsYieldDifferentResults(t *testing.T) {
+	cfg, err := NewConfig(1, 0.9, 0, time.Second)
+	if err != nil {
+		t.Fatalf("NewConfig() error = %v", err)
+	}
+	base := time.Unix(0, 0)
+
+	p1 := New(cfg).AddAt(base, 10)
+	oneGap := p1.AddAt(base.Add(time.Second), 20)
+	tenGap := p1.AddAt(base.Add(10*time.Second), 20)
+
+	if oneGap.Value() == tenGap.Value() {
+		t.Errorf("AddAt should weigh a 10-period gap differently than a 1-period gap, got the same Value() %v for both", oneGap.Value())
+	}
+}
+
+func TestAddAtDoesNotMutateReceiver(t *testing.T) {
+	cfg, err := NewConfig(5, 0.5, 0.5, time.Second)
+	if err != nil {
+		t.Fatalf("NewConfig() error = %v", err)
+	}
+	base := time.Unix(0, 0)
+
+	p := New(cfg).AddAt(base, 10)
+	before := p.Value()
+
+	_ = p.AddAt(base.Add(time.Second), 20)
+
+	if p.Value() != before {
+		t.Errorf("AddAt mutated the receiver: Value() changed from %v to %v", before, p.Value())
+	}
+}
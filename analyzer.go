@@ -0,0 +1,129 @@
+package pewma
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Sample is a single timestamped observation fed into an Analyzer.
+type Sample struct {
+	Time  time.Time
+	Value Value
+}
+
+// Event is the result of analyzing one Sample.
+type Event struct {
+	Time   time.Time
+	Value  Value
+	Status Status
+	Phi    float64
+	S1     Value
+	StdDev float64
+}
+
+// Analyzer wraps a PEWMA for the common "consume a stream, get anomaly
+// events" use case. It owns the underlying detector and serializes access to
+// it with a mutex, so unlike a raw PEWMA it is safe for multiple goroutines
+// to observe concurrently.
+type Analyzer struct {
+	mu        sync.Mutex
+	pewma     *PEWMA
+	threshold float64
+	bufSize   int
+}
+
+// NewAnalyzer returns an Analyzer backed by a PEWMA configured with cfg,
+// classifying samples against threshold.
+func NewAnalyzer(cfg Config, threshold float64) *Analyzer {
+	return &Analyzer{
+		pewma:     New(cfg),
+		threshold: threshold,
+		bufSize:   1,
+	}
+}
+
+// WithBuffer sets the buffer size of the channel Run returns, letting
+// callers trade memory for resilience against a slow consumer. It must be
+// called before Run.
+func (a *Analyzer) WithBuffer(n int) *Analyzer {
+	a.bufSize = n
+	return a
+}
+
+// Run consumes in until it is closed or ctx is cancelled, emitting an Event
+// for each Sample on the returned channel. On ctx cancellation it offers one
+// final Event capturing the detector's state as of the last processed
+// Sample, then closes the output channel — even if cancellation happens
+// while a per-sample send is still blocked on a full buffer. The final Event
+// is sent only if the output buffer has room for it at that moment; when the
+// buffer is full, or no Sample was processed, no final Event is emitted.
+func (a *Analyzer) Run(ctx context.Context, in <-chan Sample) <-chan Event {
+	out := make(chan Event, a.bufSize)
+	go func() {
+		defer close(out)
+		var last Sample
+		seen := false
+		emitTerminal := func() {
+			if !seen {
+				return
+			}
+			select {
+			case out <- a.terminalEvent(last):
+			default:
+			}
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				emitTerminal()
+				return
+			case s, ok := <-in:
+				if !ok {
+					return
+				}
+				last, seen = s, true
+				select {
+				case out <- a.analyze(s):
+				case <-ctx.Done():
+					emitTerminal()
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// terminalEvent reports the detector's current, already up-to-date state as
+// of the given Sample, without folding it in again.
+func (a *Analyzer) terminalEvent(last Sample) Event {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	status, phi := a.pewma.AnalyzeDetailed(last.Value, a.threshold)
+	return Event{
+		Time:   last.Time,
+		Value:  last.Value,
+		Status: status,
+		Phi:    phi,
+		S1:     a.pewma.Value(),
+		StdDev: a.pewma.StdDev(),
+	}
+}
+
+// analyze classifies s against the underlying detector and folds it in,
+// serializing both under the Analyzer's mutex.
+func (a *Analyzer) analyze(s Sample) Event {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	status, phi := a.pewma.AnalyzeDetailed(s.Value, a.threshold)
+	a.pewma = a.pewma.AddAt(s.Time, s.Value)
+	return Event{
+		Time:   s.Time,
+		Value:  s.Value,
+		Status: status,
+		Phi:    phi,
+		S1:     a.pewma.Value(),
+		StdDev: a.pewma.StdDev(),
+	}
+}
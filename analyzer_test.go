@@ -0,0 +1,87 @@
+package pewma
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestAnalyzerRunEmitsEventPerSample(t *testing.T) {
+	cfg, err := NewConfig(3, 0.5, 0.5, time.Second)
+	if err != nil {
+		t.Fatalf("NewConfig() error = %v", err)
+	}
+	a := NewAnalyzer(cfg, 0.05).WithBuffer(4)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	in := make(chan Sample, 4)
+	base := time.Unix(0, 0)
+	samples := []Sample{
+		{Time: base, Value: 10},
+		{Time: base.Add(time.Second), Value: 11},
+		{Time: base.Add(2 * time.Second), Value: 9},
+	}
+	for _, s := range samples {
+		in <- s
+	}
+	close(in)
+
+	out := a.Run(ctx, in)
+
+	var events []Event
+	for ev := range out {
+		events = append(events, ev)
+	}
+
+	if len(events) != len(samples) {
+		t.Fatalf("got %d events, want %d", len(events), len(samples))
+	}
+	for i, ev := range events {
+		if ev.Value != samples[i].Value {
+			t.Errorf("events[%d].Value = %v, want %v", i, ev.Value, samples[i].Value)
+		}
+	}
+}
+
+// TestAnalyzerRunClosesPromptlyOnCancelWithBlockedSend pins down a scenario
+// where a per-sample send is genuinely blocked on a full buffer (no consumer
+// has read anything yet) when ctx is cancelled. Run must still return and
+// close its output channel promptly instead of staying blocked forever on
+// the per-sample send.
+func TestAnalyzerRunClosesPromptlyOnCancelWithBlockedSend(t *testing.T) {
+	cfg, err := NewConfig(2, 0.5, 0.5, time.Second)
+	if err != nil {
+		t.Fatalf("NewConfig() error = %v", err)
+	}
+	a := NewAnalyzer(cfg, 0.05).WithBuffer(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	in := make(chan Sample)
+	out := a.Run(ctx, in)
+
+	base := time.Unix(0, 0)
+	// The first sample's event fits in the size-1 buffer without a reader.
+	in <- Sample{Time: base, Value: 10}
+	// The second sample's event cannot: the buffer is still full of the
+	// first, unread event, so Run is now genuinely blocked trying to send it.
+	in <- Sample{Time: base.Add(time.Second), Value: 11}
+
+	cancel()
+	close(in)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range out {
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not close its output channel after ctx cancellation while a send was blocked")
+	}
+}
@@ -0,0 +1,202 @@
+package pewma
+
+import (
+	"math"
+	"time"
+)
+
+// MovingAverage is the common interface implemented by every smoothing
+// strategy in this package. It lets callers A/B different smoothers against
+// the same input stream without rewriting call sites.
+type MovingAverage interface {
+	Add(Value)
+	Value() Value
+	StdDev() float64
+	Analyze(Value, float64) Status
+}
+
+var (
+	_ MovingAverage = (*pewmaMA)(nil)
+	_ MovingAverage = (*SimpleEWMA)(nil)
+	_ MovingAverage = (*WarmupEWMA)(nil)
+)
+
+// pewmaMA adapts PEWMA's immutable, copy-on-write Add to the
+// MovingAverage interface by holding the latest *PEWMA and swapping it in on
+// each Add, so the underlying PEWMA keeps its copy-on-write semantics for
+// direct callers (e.g. those holding onto a prior *PEWMA as a historical
+// snapshot) while still being usable anywhere a MovingAverage is expected.
+type pewmaMA struct {
+	p *PEWMA
+}
+
+// NewPEWMAMovingAverage adapts p to the MovingAverage interface.
+func NewPEWMAMovingAverage(p *PEWMA) MovingAverage {
+	return &pewmaMA{p: p}
+}
+
+func (m *pewmaMA) Add(v Value) { m.p = m.p.Add(v) }
+
+func (m *pewmaMA) Value() Value { return m.p.Value() }
+
+func (m *pewmaMA) StdDev() float64 { return m.p.StdDev() }
+
+func (m *pewmaMA) Analyze(v Value, threshold float64) Status { return m.p.Analyze(v, threshold) }
+
+// SimpleEWMA is a plain exponentially weighted moving average with a fixed
+// alpha and no probabilistic weighting, for callers who just want a boring
+// EWMA (e.g. smoothing RTT) rather than PEWMA's adaptive outlier rejection.
+type SimpleEWMA struct {
+	alpha        Value
+	initialized  bool
+	s1           Value
+	s2           Value
+	stdDeviation float64
+}
+
+// NewSimpleEWMA returns a SimpleEWMA using the supplied alpha as the fixed
+// weight given to each new sample on every Add (the running average keeps
+// 1-alpha).
+func NewSimpleEWMA(alpha float64) *SimpleEWMA {
+	return &SimpleEWMA{alpha: Value(alpha)}
+}
+
+// Add captures the supplied value, updating the running mean and standard
+// deviation in place. The first call seeds the average directly with v.
+func (e *SimpleEWMA) Add(v Value) {
+	if !e.initialized {
+		e.s1 = v
+		e.s2 = v.square()
+		e.stdDeviation = 0
+		e.initialized = true
+		return
+	}
+	s1 := e.alpha*v + (1-e.alpha)*e.s1
+	s2 := e.alpha*v.square() + (1-e.alpha)*e.s2
+	e.s1 = s1
+	e.s2 = s2
+	e.stdDeviation = (s2 - s1.square()).sqrt()
+}
+
+// Value returns the current running average.
+func (e *SimpleEWMA) Value() Value {
+	return e.s1
+}
+
+// StdDev returns the current running standard deviation.
+func (e *SimpleEWMA) StdDev() float64 {
+	return e.stdDeviation
+}
+
+// Analyze reports Outlier when the Gaussian fitted from the running average
+// and standard deviation assigns v a probability density of threshold or
+// less, InOrdinary otherwise — the same density-cutoff convention as
+// PEWMA.Analyze, so callers can swap smoothers without rescaling threshold.
+// SimpleEWMA has no training period, so it never reports InTraining.
+func (e *SimpleEWMA) Analyze(v Value, threshold float64) Status {
+	if e.stdDeviation == 0 {
+		return InOrdinary
+	}
+	z := float64(v-e.s1) / e.stdDeviation
+	if gaussianPt(z) <= threshold {
+		return Outlier
+	}
+	return InOrdinary
+}
+
+// WarmupEWMA averages its first warmupSamples arithmetically, then switches
+// to exponential smoothing with a fixed alpha. This avoids the large swings
+// a plain EWMA shows while it is still absorbing its first few samples.
+type WarmupEWMA struct {
+	warmupSamples int
+	captured      []Value
+	ewma          *SimpleEWMA
+}
+
+// NewWarmupEWMA returns a WarmupEWMA that arithmetically averages its first
+// warmupSamples values before switching to exponential smoothing with alpha.
+func NewWarmupEWMA(warmupSamples int, alpha float64) *WarmupEWMA {
+	return &WarmupEWMA{
+		warmupSamples: warmupSamples,
+		ewma:          NewSimpleEWMA(alpha),
+	}
+}
+
+// Add captures the supplied value. During warmup it is folded into a plain
+// arithmetic mean; once warmupSamples have been captured, the underlying
+// SimpleEWMA is seeded with that mean and takes over.
+func (w *WarmupEWMA) Add(v Value) {
+	if len(w.captured) < w.warmupSamples {
+		w.captured = append(w.captured, v)
+		if len(w.captured) == w.warmupSamples {
+			w.ewma.Add(w.mean())
+		}
+		return
+	}
+	w.ewma.Add(v)
+}
+
+func (w *WarmupEWMA) mean() Value {
+	var sum Value
+	for _, v := range w.captured {
+		sum += v
+	}
+	return sum / Value(len(w.captured))
+}
+
+// Value returns the current average: the arithmetic mean of the samples
+// captured so far during warmup, or the underlying EWMA's value afterward.
+func (w *WarmupEWMA) Value() Value {
+	if len(w.captured) < w.warmupSamples {
+		if len(w.captured) == 0 {
+			return 0
+		}
+		return w.mean()
+	}
+	return w.ewma.Value()
+}
+
+// StdDev returns the underlying EWMA's standard deviation; it is 0 until
+// warmup completes.
+func (w *WarmupEWMA) StdDev() float64 {
+	if len(w.captured) < w.warmupSamples {
+		return 0
+	}
+	return w.ewma.StdDev()
+}
+
+// Analyze behaves like SimpleEWMA.Analyze once warmup has completed, and
+// reports InTraining while samples are still being collected for the initial
+// arithmetic mean.
+func (w *WarmupEWMA) Analyze(v Value, threshold float64) Status {
+	if len(w.captured) < w.warmupSamples {
+		return InTraining
+	}
+	return w.ewma.Analyze(v, threshold)
+}
+
+// presetAlpha derives the fixed alpha that gives an EWMA a half-life of
+// halfLife when it receives one sample every samplingPeriod: after halfLife
+// has elapsed, a value's weight in the running average has decayed to
+// exactly half.
+func presetAlpha(halfLife, samplingPeriod time.Duration) float64 {
+	return 1 - math.Exp(-math.Ln2*samplingPeriod.Seconds()/halfLife.Seconds())
+}
+
+// NewEWMA1 returns a SimpleEWMA tuned to a 1-minute half-life, given that
+// samples arrive roughly every samplingPeriod.
+func NewEWMA1(samplingPeriod time.Duration) *SimpleEWMA {
+	return NewSimpleEWMA(presetAlpha(time.Minute, samplingPeriod))
+}
+
+// NewEWMA5 returns a SimpleEWMA tuned to a 5-minute half-life, given that
+// samples arrive roughly every samplingPeriod.
+func NewEWMA5(samplingPeriod time.Duration) *SimpleEWMA {
+	return NewSimpleEWMA(presetAlpha(5*time.Minute, samplingPeriod))
+}
+
+// NewEWMA15 returns a SimpleEWMA tuned to a 15-minute half-life, given that
+// samples arrive roughly every samplingPeriod.
+func NewEWMA15(samplingPeriod time.Duration) *SimpleEWMA {
+	return NewSimpleEWMA(presetAlpha(15*time.Minute, samplingPeriod))
+}
@@ -0,0 +1,101 @@
+package pewma
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSimpleEWMATracksRunningAverage(t *testing.T) {
+	e := NewSimpleEWMA(0.5)
+	e.Add(10)
+	if e.Value() != 10 {
+		t.Fatalf("Value() after first Add = %v, want 10 (seeded directly)", e.Value())
+	}
+	e.Add(20)
+	if got, want := e.Value(), Value(15); got != want {
+		t.Errorf("Value() after second Add = %v, want %v", got, want)
+	}
+}
+
+func TestSimpleEWMAAnalyzeUsesDensityThreshold(t *testing.T) {
+	e := NewSimpleEWMA(0.3)
+	for _, v := range []Value{10, 10, 11, 9, 10, 12, 8} {
+		e.Add(v)
+	}
+
+	const threshold = 0.05
+	if got := e.Analyze(10, threshold); got != InOrdinary {
+		t.Errorf("Analyze(near mean) = %v, want InOrdinary", got)
+	}
+	if got := e.Analyze(1000, threshold); got != Outlier {
+		t.Errorf("Analyze(far from mean) = %v, want Outlier", got)
+	}
+}
+
+func TestWarmupEWMAReportsInTrainingUntilWarmupCompletes(t *testing.T) {
+	w := NewWarmupEWMA(3, 0.5)
+
+	w.Add(10)
+	if got := w.Analyze(10, 0.05); got != InTraining {
+		t.Errorf("Analyze() during warmup = %v, want InTraining", got)
+	}
+
+	w.Add(20)
+	w.Add(30)
+	if got := w.Analyze(20, 0.05); got == InTraining {
+		t.Errorf("Analyze() after warmup = %v, want not InTraining", got)
+	}
+
+	if got, want := w.Value(), Value(20); got != want {
+		t.Errorf("Value() right after warmup = %v, want arithmetic mean %v", got, want)
+	}
+}
+
+func TestPresetAlphaHitsAdvertisedHalfLife(t *testing.T) {
+	e := NewEWMA1(time.Second)
+	e.Add(10)
+	for i := 0; i < 60; i++ {
+		e.Add(20)
+	}
+	if got, want := float64(e.Value()), 15.0; math.Abs(got-want) > 1e-9 {
+		t.Errorf("NewEWMA1 value after one half-life = %v, want %v", got, want)
+	}
+}
+
+// TestMovingAverageImplementationsAreInterchangeable drives every
+// MovingAverage implementation through the same stream and the same
+// threshold convention purely via the interface, and checks that a wildly
+// out-of-range value is flagged as an Outlier by all of them once trained.
+func TestMovingAverageImplementationsAreInterchangeable(t *testing.T) {
+	const threshold = 0.05
+	samples := []Value{10, 11, 9, 10, 12, 8, 10, 9, 11, 10}
+
+	mas := map[string]MovingAverage{
+		"pewma":  NewPEWMAMovingAverage(New(mustConfig(t))),
+		"simple": NewSimpleEWMA(0.3),
+		"warmup": NewWarmupEWMA(3, 0.3),
+	}
+
+	for name, ma := range mas {
+		for _, v := range samples {
+			ma.Add(v)
+		}
+		if got := ma.Analyze(1000, threshold); got != Outlier {
+			t.Errorf("%s: Analyze(1000, %v) = %v, want Outlier", name, threshold, got)
+		}
+	}
+}
+
+func mustConfig(t *testing.T) Config {
+	t.Helper()
+	// trainingPeriod is kept at 1 so the PEWMA leaves InTraining after its
+	// very first sample, matching the other two implementations (which have
+	// no or a short warmup) closely enough to drive all three through the
+	// same stream.
+	cfg, err := NewConfig(1, 0.5, 0.5, time.Second)
+	if err != nil {
+		t.Fatalf("NewConfig() error = %v", err)
+	}
+	return cfg
+}
@@ -3,6 +3,7 @@ package pewma
 import (
 	"errors"
 	"math"
+	"time"
 )
 
 // Value represents acceptable and calculatable.
@@ -18,23 +19,28 @@ func (v Value) square() Value {
 
 // Config represents coefficients for calculating specified time series.
 type Config struct {
-	trainingPeriod int     // T
-	alpha0Weight   float64 // α
-	betaWeight     float64 // β
+	trainingPeriod int           // T
+	alpha0Weight   float64       // α
+	betaWeight     float64       // β
+	samplingPeriod time.Duration // expected interval between samples, used by AddAt
 }
 
 // NewConfig returns Config object from supplied values with validation.
-func NewConfig(t int, alpha0 float64, beta float64) (Config, error) {
+func NewConfig(t int, alpha0 float64, beta float64, samplingPeriod time.Duration) (Config, error) {
 	if alpha0 <= 0 || 1 <= alpha0 {
 		return Config{}, errors.New("alpha0 weight is invalid")
 	}
 	if beta < 0 || 1 <= beta {
 		return Config{}, errors.New("beta weight is invalid")
 	}
+	if samplingPeriod < 0 {
+		return Config{}, errors.New("sampling period is invalid")
+	}
 	return Config{
 		trainingPeriod: t,
-		alpha0Weight:   adapt,
+		alpha0Weight:   alpha0,
 		betaWeight:     beta,
+		samplingPeriod: samplingPeriod,
 	}, nil
 }
 
@@ -50,10 +56,15 @@ func (f factors) zt(v Value) float64 {
 	return float64(v-f.s1) / f.stdDeviation
 }
 
+// gaussianPt returns the standard normal probability density at z, i.e.
+// Pt ← exp(-(Zt^2)/2)/√2π. threshold values compared against it (see
+// detectAnomaly) are therefore density cutoffs, not z-scores.
+func gaussianPt(z float64) float64 {
+	return math.Exp(-(z*z)/2) / math.Sqrt(2*math.Pi)
+}
+
 func (f factors) pt(v Value) float64 {
-	// Pt ← exp(-(Zt^2)/2)/√2π
-	zt := f.zt(v)
-	return math.Exp(-(zt*zt)/2) / math.Sqrt(2*math.Pi)
+	return gaussianPt(f.zt(v))
 }
 
 func (f factors) detectAnomaly(threshold float64, v Value) bool {
@@ -81,6 +92,7 @@ type PEWMA struct {
 	captured []Value
 	config   Config
 	factors  factors
+	lastTS   time.Time
 }
 
 // New returns PEWMA object.
@@ -140,7 +152,8 @@ func (p *PEWMA) pushAndPop(v Value) []Value {
 	return newCaptured
 }
 
-// Add provides capture supplied value and new PEWMA object.
+// Add provides capture supplied value and new PEWMA object, leaving the
+// receiver untouched.
 func (p *PEWMA) Add(v Value) *PEWMA {
 	alpha := p.alpha(v)
 
@@ -148,5 +161,16 @@ func (p *PEWMA) Add(v Value) *PEWMA {
 		config:   p.config,
 		captured: p.pushAndPop(v),
 		factors:  p.factors.New(alpha, v),
+		lastTS:   p.lastTS,
 	}
 }
+
+// Value returns the detector's current fitted mean (s1).
+func (p *PEWMA) Value() Value {
+	return p.factors.s1
+}
+
+// StdDev returns the detector's current fitted standard deviation.
+func (p *PEWMA) StdDev() float64 {
+	return p.factors.stdDeviation
+}
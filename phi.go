@@ -0,0 +1,42 @@
+package pewma
+
+import "math"
+
+// maxPhi is the value Phi clamps to when the upper-tail probability
+// underflows to 0 (i.e. v is so far into the tail that it is indistinguishable
+// from certainty), since -log10(0) is +Inf and callers expect a finite score.
+// It is above every finite score, so Phi stays monotonic across the clamp.
+const maxPhi = math.MaxFloat64
+
+func (f factors) phi(v Value) float64 {
+	z := f.zt(v)
+	if z < 0 {
+		z = -z
+	}
+	pLater := 0.5 * math.Erfc(z/math.Sqrt2)
+	if pLater <= 0 {
+		return maxPhi
+	}
+	return -math.Log10(pLater)
+}
+
+// Phi returns a continuous, phi-accrual-style suspicion score for v, derived
+// from the Gaussian currently fitted in factors. Unlike Analyze, it is
+// unbounded and grows monotonically the further v sits into the tail:
+// Phi == 1 corresponds to roughly a 10% false-positive rate, Phi == 2 to
+// roughly 1%, and so on, which lets callers drive adaptive alerting
+// (warn/critical thresholds, hysteresis) instead of a hard cutoff. During
+// InTraining it always returns 0.
+func (p *PEWMA) Phi(v Value) float64 {
+	if len(p.captured) < p.config.trainingPeriod {
+		return 0
+	}
+	return p.factors.phi(v)
+}
+
+// AnalyzeDetailed behaves like Analyze but additionally returns the
+// continuous Phi score for v, so callers can drive adaptive alerting instead
+// of relying solely on the binary Status.
+func (p *PEWMA) AnalyzeDetailed(v Value, threshold float64) (Status, float64) {
+	return p.Analyze(v, threshold), p.Phi(v)
+}
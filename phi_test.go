@@ -0,0 +1,58 @@
+package pewma
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestPhiZeroDuringTraining(t *testing.T) {
+	cfg, err := NewConfig(5, 0.5, 0.5, time.Second)
+	if err != nil {
+		t.Fatalf("NewConfig() error = %v", err)
+	}
+	p := New(cfg)
+	if got := p.Phi(100); got != 0 {
+		t.Errorf("Phi() during training = %v, want 0", got)
+	}
+}
+
+func TestPhiGrowsWithDistanceFromMean(t *testing.T) {
+	cfg, err := NewConfig(5, 0.5, 0.5, time.Second)
+	if err != nil {
+		t.Fatalf("NewConfig() error = %v", err)
+	}
+	p := New(cfg)
+	for _, v := range []Value{10, 11, 9, 10, 12, 8} {
+		p = p.Add(v)
+	}
+
+	near := p.Phi(10)
+	far := p.Phi(1000)
+	if !(far > near) {
+		t.Errorf("Phi(far) = %v, want > Phi(near) = %v", far, near)
+	}
+	if math.IsInf(far, 1) || math.IsNaN(far) {
+		t.Errorf("Phi(far) = %v, want a clamped finite value", far)
+	}
+}
+
+func TestAnalyzeDetailedMatchesAnalyzeAndPhi(t *testing.T) {
+	cfg, err := NewConfig(5, 0.5, 0.5, time.Second)
+	if err != nil {
+		t.Fatalf("NewConfig() error = %v", err)
+	}
+	p := New(cfg)
+	for _, v := range []Value{10, 11, 9, 10, 12, 8} {
+		p = p.Add(v)
+	}
+
+	const threshold = 0.05
+	gotStatus, gotPhi := p.AnalyzeDetailed(50, threshold)
+	if wantStatus := p.Analyze(50, threshold); gotStatus != wantStatus {
+		t.Errorf("AnalyzeDetailed() status = %v, want %v", gotStatus, wantStatus)
+	}
+	if wantPhi := p.Phi(50); gotPhi != wantPhi {
+		t.Errorf("AnalyzeDetailed() phi = %v, want %v", gotPhi, wantPhi)
+	}
+}
@@ -0,0 +1,68 @@
+package pewma
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Snapshot is an exported, JSON-friendly capture of a PEWMA's internal
+// state, suitable for persisting across process restarts so a long-running
+// detector doesn't have to sit through its training period again. LastTS is
+// included so that, after a Restore, the first AddAt call on the restored
+// detector still compares against the real previous sample time instead of
+// treating the next sample as the very first one ever seen.
+type Snapshot struct {
+	Captured          []Value
+	S1                Value
+	S2                Value
+	StdDeviation      float64
+	LastTS            time.Time
+	ConfigFingerprint string
+}
+
+// configFingerprint identifies the Config a Snapshot was captured with, so
+// Restore can detect a mismatched Config before rehydrating from stale state.
+func configFingerprint(c Config) string {
+	return fmt.Sprintf("%d:%g:%g:%s", c.trainingPeriod, c.alpha0Weight, c.betaWeight, c.samplingPeriod)
+}
+
+// Snapshot captures the detector's current state for later persistence.
+func (p *PEWMA) Snapshot() Snapshot {
+	captured := make([]Value, len(p.captured))
+	copy(captured, p.captured)
+	return Snapshot{
+		Captured:          captured,
+		S1:                p.factors.s1,
+		S2:                p.factors.s2,
+		StdDeviation:      p.factors.stdDeviation,
+		LastTS:            p.lastTS,
+		ConfigFingerprint: configFingerprint(p.config),
+	}
+}
+
+// ErrConfigMismatch is returned by Restore when the supplied Config does not
+// match the Config the Snapshot was captured with, so callers can decide
+// whether to discard the snapshot and retrain instead of rehydrating state
+// that no longer corresponds to the given coefficients.
+var ErrConfigMismatch = errors.New("pewma: snapshot config does not match supplied config")
+
+// Restore rehydrates a PEWMA from a Snapshot previously returned by
+// Snapshot, validating that cfg matches the Config it was captured with.
+func Restore(cfg Config, s Snapshot) (*PEWMA, error) {
+	if configFingerprint(cfg) != s.ConfigFingerprint {
+		return nil, ErrConfigMismatch
+	}
+	captured := make([]Value, len(s.Captured))
+	copy(captured, s.Captured)
+	return &PEWMA{
+		captured: captured,
+		config:   cfg,
+		factors: factors{
+			s1:           s.S1,
+			s2:           s.S2,
+			stdDeviation: s.StdDeviation,
+		},
+		lastTS: s.LastTS,
+	}, nil
+}
@@ -0,0 +1,91 @@
+package pewma
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSnapshotRestoreRoundTrip(t *testing.T) {
+	cfg, err := NewConfig(5, 0.5, 0.5, time.Second)
+	if err != nil {
+		t.Fatalf("NewConfig() error = %v", err)
+	}
+
+	p := New(cfg)
+	for _, v := range []Value{10, 11, 9, 10, 12, 8, 13} {
+		p = p.Add(v)
+	}
+
+	restored, err := Restore(cfg, p.Snapshot())
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+
+	if restored.Value() != p.Value() {
+		t.Errorf("restored.Value() = %v, want %v", restored.Value(), p.Value())
+	}
+	if restored.StdDev() != p.StdDev() {
+		t.Errorf("restored.StdDev() = %v, want %v", restored.StdDev(), p.StdDev())
+	}
+	if len(restored.captured) != len(p.captured) {
+		t.Errorf("restored.captured = %v, want %v", restored.captured, p.captured)
+	}
+
+	const threshold = 0.05
+	if got, want := restored.Analyze(10, threshold), p.Analyze(10, threshold); got != want {
+		t.Errorf("restored.Analyze() = %v, want %v", got, want)
+	}
+}
+
+// TestSnapshotRestorePreservesLastTS verifies that Restore carries lastTS
+// forward, so that AddAt on a restored detector measures elapsed time
+// against the real previous sample instead of treating the next sample as
+// the first one ever seen.
+func TestSnapshotRestorePreservesLastTS(t *testing.T) {
+	cfg, err := NewConfig(2, 0.5, 0.5, time.Second)
+	if err != nil {
+		t.Fatalf("NewConfig() error = %v", err)
+	}
+
+	base := time.Unix(0, 0)
+	p := New(cfg)
+	p = p.AddAt(base, 10)
+	p = p.AddAt(base.Add(time.Second), 11)
+
+	restored, err := Restore(cfg, p.Snapshot())
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+
+	gotNext := restored.AddAt(base.Add(11*time.Second), 11)
+	wantNext := p.AddAt(base.Add(11*time.Second), 11)
+
+	if gotNext.Value() != wantNext.Value() {
+		t.Errorf("AddAt after restore: Value() = %v, want %v (lastTS not preserved)", gotNext.Value(), wantNext.Value())
+	}
+	if gotNext.StdDev() != wantNext.StdDev() {
+		t.Errorf("AddAt after restore: StdDev() = %v, want %v (lastTS not preserved)", gotNext.StdDev(), wantNext.StdDev())
+	}
+}
+
+func TestRestoreConfigMismatch(t *testing.T) {
+	cfg, err := NewConfig(5, 0.5, 0.5, time.Second)
+	if err != nil {
+		t.Fatalf("NewConfig() error = %v", err)
+	}
+	p := New(cfg)
+	for _, v := range []Value{10, 11, 9, 10, 12} {
+		p = p.Add(v)
+	}
+	snap := p.Snapshot()
+
+	mismatched, err := NewConfig(10, 0.9, 0.5, time.Second)
+	if err != nil {
+		t.Fatalf("NewConfig() error = %v", err)
+	}
+
+	if _, err := Restore(mismatched, snap); !errors.Is(err, ErrConfigMismatch) {
+		t.Fatalf("Restore() error = %v, want ErrConfigMismatch", err)
+	}
+}